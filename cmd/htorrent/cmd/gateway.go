@@ -30,6 +30,17 @@ const (
 	upnpFlag         = "upnp"
 	protocolsFlag    = "protocols"
 	downloadDirFlag  = "download-dir"
+	webSeedsFlag     = "webseeds"
+	maxUploadRateFlag   = "max-upload-rate"
+	maxDownloadRateFlag = "max-download-rate"
+	blocklistFlag        = "blocklist"
+	blocklistURLFlag     = "blocklist-url"
+	blocklistRefreshFlag = "blocklist-refresh"
+	storageBackendFlag   = "storage-backend"
+	seedFlag      = "seed"
+	seedRatioFlag = "seed-ratio"
+	watchDirFlag  = "watch-dir"
+	readaheadBytesFlag = "readahead-bytes"
 )
 
 var gatewayCmd = &cobra.Command{
@@ -70,6 +81,16 @@ var gatewayCmd = &cobra.Command{
 			}
 		}
 
+		// Parse webseeds from comma-separated string to slice
+		webSeedsStr := viper.GetString(webSeedsFlag)
+		var webSeeds []string
+		if webSeedsStr != "" {
+			webSeeds = strings.Split(webSeedsStr, ",")
+			for i, w := range webSeeds {
+				webSeeds[i] = strings.TrimSpace(w)
+			}
+		}
+
 		gateway := server.NewGateway(
 			addr.String(),
 					     viper.GetString(storageFlag),
@@ -80,6 +101,17 @@ var gatewayCmd = &cobra.Command{
 					     viper.GetBool(upnpFlag),
 					     protocols,
 			       viper.GetString(downloadDirFlag),
+					     webSeeds,
+					     viper.GetInt64(maxUploadRateFlag),
+					     viper.GetInt64(maxDownloadRateFlag),
+					     viper.GetString(blocklistFlag),
+					     viper.GetString(blocklistURLFlag),
+					     viper.GetDuration(blocklistRefreshFlag),
+					     viper.GetString(storageBackendFlag),
+					     viper.GetBool(seedFlag),
+					     viper.GetFloat64(seedRatioFlag),
+					     viper.GetString(watchDirFlag),
+					     viper.GetInt64(readaheadBytesFlag),
 					     func(torrentMetrics v1.TorrentMetrics, fileMetrics v1.FileMetrics) {
 						     log.Debug().
 						     Str("magnet", torrentMetrics.Magnet).
@@ -147,6 +179,17 @@ func init() {
 	gatewayCmd.PersistentFlags().BoolP(upnpFlag, "u", true, "Enable UPnP port forwarding on router")
 	gatewayCmd.PersistentFlags().StringP(protocolsFlag, "p", "tcp,utp", "Comma-separated list of protocols to use (tcp, utp)")
 	gatewayCmd.PersistentFlags().StringP(downloadDirFlag, "o", "", "Directory to download torrents to (defaults to storage directory if not specified)")
+	gatewayCmd.PersistentFlags().StringP(webSeedsFlag, "w", "", "Comma-separated list of BEP 19 webseed URLs to use for all torrents, in addition to any `url-list` entries from the torrent's metainfo and the `webseeds` query parameter")
+	gatewayCmd.PersistentFlags().Int64(maxUploadRateFlag, 0, "Maximum upload rate in bytes/sec for seeding back to the swarm (0 means unlimited); applies client-wide, since /stream has no upload direction to override per-request")
+	gatewayCmd.PersistentFlags().Int64(maxDownloadRateFlag, 0, "Maximum download rate in bytes/sec (0 means unlimited); applies client-wide. The `maxDownloadRate` query parameter on /stream adds a further per-request limit on top of it, so it can lower but not raise this one")
+	gatewayCmd.PersistentFlags().String(blocklistFlag, "", "Path to a P2P/eMule-format IP blocklist file to reject peers with")
+	gatewayCmd.PersistentFlags().String(blocklistURLFlag, "", "URL to fetch a P2P/eMule-format IP blocklist from instead of a local file")
+	gatewayCmd.PersistentFlags().Duration(blocklistRefreshFlag, 0, "Interval at which to refresh the IP blocklist (0 disables automatic refresh)")
+	gatewayCmd.PersistentFlags().String(storageBackendFlag, "file", "Storage backend to use for downloaded data: file (plain per-file storage), mmap (memory-mapped files, best for /stream of large media), piece-file (same per-file storage as \"file\", but paths are keyed by infohash instead of torrent name) or boltdb (single-file embedded database, fewest open file descriptors)")
+	gatewayCmd.PersistentFlags().Bool(seedFlag, false, "Keep seeding torrents back to the swarm after they finish downloading")
+	gatewayCmd.PersistentFlags().Float64(seedRatioFlag, 0, "Stop seeding a torrent once its upload/download ratio exceeds this value (0 means seed indefinitely)")
+	gatewayCmd.PersistentFlags().String(watchDirFlag, "", "Directory of .torrent files to watch; files added/removed are seeded/dropped automatically")
+	gatewayCmd.PersistentFlags().Int64(readaheadBytesFlag, 4*1024*1024, "Default /stream readahead window in bytes; can be overridden per-request with the `readahead` query parameter")
 
 	viper.AutomaticEnv()
 
@@ -0,0 +1,98 @@
+package v1
+
+import "time"
+
+// File is a file that is part of a torrent.
+type File struct {
+	Path   string `json:"path"`
+	Length int64  `json:"length"`
+}
+
+// Info describes a torrent's metadata, as returned by the `/info` endpoint.
+type Info struct {
+	Name         string `json:"name"`
+	InfoHash     string `json:"infoHash"`
+	CreationDate int64  `json:"creationDate"`
+	Description  string `json:"description"`
+	Files        []File `json:"files"`
+}
+
+// FileMetrics describes the download progress of a single file in a torrent.
+type FileMetrics struct {
+	Path      string `json:"path"`
+	Length    int64  `json:"length"`
+	Completed int64  `json:"completed"`
+
+	// HeadPiece and ReadaheadPieces describe the current /stream readahead
+	// window: the piece index at the offset of the most recent seek (reads
+	// do not move it), and how many pieces ahead of it are being prioritized.
+	HeadPiece       int `json:"headPiece"`
+	ReadaheadPieces int `json:"readaheadPieces"`
+}
+
+// PieceStateRun is a compressed run of consecutive pieces sharing the same
+// completion state, derived from Torrent.PieceStateRuns() with Offset and
+// Length converted from piece counts into bytes.
+type PieceStateRun struct {
+	Offset   int64 `json:"offset"`
+	Length   int64 `json:"length"`
+	Complete bool  `json:"complete"`
+	Partial  bool  `json:"partial"`
+	Checking bool  `json:"checking"`
+}
+
+// Peer describes one connection to a torrent's swarm.
+type Peer struct {
+	Addr         string  `json:"addr"`
+	ClientID     string  `json:"clientId"`
+	DownloadRate float64 `json:"downloadRate"`
+	IsWebSeed    bool    `json:"isWebSeed"`
+}
+
+// TorrentMetrics describes the download progress of a torrent, as returned by the `/metrics` endpoint.
+type TorrentMetrics struct {
+	Magnet   string        `json:"magnet"`
+	InfoHash string        `json:"infoHash"`
+	Peers    int           `json:"peers"`
+	Files    []FileMetrics `json:"files"`
+
+	// BytesCompleted and Length are the torrent-wide completion totals, as
+	// opposed to the per-file figures in Files.
+	BytesCompleted int64 `json:"bytesCompleted"`
+	Length         int64 `json:"length"`
+
+	// PieceStateRuns is a run-length-encoded view of piece completion state,
+	// cheaper to render than one entry per piece.
+	PieceStateRuns []PieceStateRun `json:"pieceStateRuns"`
+
+	// DownloadRate and UploadRate are instantaneous, sampled byte rates in bytes/sec.
+	DownloadRate int64 `json:"downloadRate"`
+	UploadRate   int64 `json:"uploadRate"`
+
+	// MaxDownloadRate and MaxUploadRate are the currently configured rate limits in
+	// bytes/sec, or 0 if unlimited.
+	MaxDownloadRate int64 `json:"maxDownloadRate"`
+	MaxUploadRate   int64 `json:"maxUploadRate"`
+
+	// BytesWritten, SeedRatio and IsSeeding describe this gateway's contribution
+	// back to the swarm when running with --seed.
+	BytesWritten int64   `json:"bytesWritten"`
+	SeedRatio    float64 `json:"seedRatio"`
+	IsSeeding    bool    `json:"isSeeding"`
+
+	// HalfOpenPeers and ActivePeers are connection counts in progress vs.
+	// fully established.
+	HalfOpenPeers int `json:"halfOpenPeers"`
+	ActivePeers   int `json:"activePeers"`
+
+	// PeerDetails lists each connection to this torrent's swarm, including
+	// webseeds.
+	PeerDetails []Peer `json:"peerDetails"`
+}
+
+// BlocklistStatus describes the currently loaded IP blocklist, as returned by
+// the `/blocklist` endpoint.
+type BlocklistStatus struct {
+	Ranges      int       `json:"ranges"`
+	LastRefresh time.Time `json:"lastRefresh"`
+}
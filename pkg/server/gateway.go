@@ -5,23 +5,46 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"os"
 	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/anacrolix/torrent"
+	"github.com/anacrolix/torrent/iplist"
 	"github.com/anacrolix/torrent/storage"
+	"github.com/anacrolix/torrent/util/dirwatch"
 	"github.com/phayes/freeport"
 	v1 "github.com/pojntfx/htorrent/pkg/api/http/v1"
 	"github.com/rs/zerolog/log"
+	"golang.org/x/time/rate"
 )
 
+// defaultRateLimiterBurst is the burst size used for upload/download rate
+// limiters, sized to roughly one piece length so a limiter doesn't stall a
+// single piece request.
+const defaultRateLimiterBurst = 256 * 1024
+
+// metricsSampleInterval is how long /metrics samples torrent stats for to
+// compute an instantaneous byte rate.
+const metricsSampleInterval = 250 * time.Millisecond
+
+// seedRatioCheckInterval is how often seeding torrents are checked against
+// the configured seed ratio.
+const seedRatioCheckInterval = time.Minute
+
 var (
-	ErrEmptyMagnetLink  = errors.New("could not work with empty magnet link")
-	ErrEmptyPath        = errors.New("could not work with empty path")
-	ErrCouldNotFindPath = errors.New("could not find path in torrent")
+	ErrEmptyMagnetLink     = errors.New("could not work with empty magnet link")
+	ErrEmptyPath           = errors.New("could not work with empty path")
+	ErrCouldNotFindPath    = errors.New("could not find path in torrent")
+	ErrUnknownStorageBackend = errors.New("unknown storage backend")
 )
 
 type Gateway struct {
@@ -35,17 +58,142 @@ type Gateway struct {
 	upnp         bool
 	protocols    []string
 	downloadDir  string
+	webSeeds     []string
+	maxUploadBytesPerSec   int64
+	maxDownloadBytesPerSec int64
+	blocklist        string
+	blocklistURL     string
+	blocklistRefresh time.Duration
+	storageBackend   string
+	seed             bool
+	seedRatio        float64
+	watchDir         string
+	readaheadBytes   int64
 
 	onDownloadProgress func(torrentMetrics v1.TorrentMetrics, fileMetrics v1.FileMetrics)
 
 	torrentClient *torrent.Client
 	srv           *http.Server
 
+	blocklistState blocklistState
+	seeding        seedingSet
+
 	errs chan error
 
 	ctx context.Context
 }
 
+// seedingSet tracks the magnet links this gateway is contributing back to
+// swarms for, persisting it to disk so seeding resumes across restarts.
+type seedingSet struct {
+	mu   sync.Mutex
+	path string
+	set  map[string]struct{}
+}
+
+func (s *seedingSet) load() ([]string, error) {
+	b, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var magnets []string
+	if err := json.Unmarshal(b, &magnets); err != nil {
+		return nil, err
+	}
+
+	return magnets, nil
+}
+
+func (s *seedingSet) add(magnet string) error {
+	s.mu.Lock()
+	s.set[magnet] = struct{}{}
+	magnets := make([]string, 0, len(s.set))
+	for m := range s.set {
+		magnets = append(magnets, m)
+	}
+	s.mu.Unlock()
+
+	b, err := json.Marshal(magnets)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path, b, 0644)
+}
+
+func (s *seedingSet) remove(magnet string) error {
+	s.mu.Lock()
+	delete(s.set, magnet)
+	magnets := make([]string, 0, len(s.set))
+	for m := range s.set {
+		magnets = append(magnets, m)
+	}
+	s.mu.Unlock()
+
+	b, err := json.Marshal(magnets)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path, b, 0644)
+}
+
+// blocklistState tracks the currently loaded IP blocklist so the /blocklist
+// endpoint can report on it without racing the refresh goroutine. It also
+// implements iplist.Ranger itself and is installed as cfg.IPBlocklist, since
+// *torrent.Client has no method to hot-swap its blocklist after
+// construction: refreshing the blocklist means swapping the list held here,
+// which the client consults on every lookup.
+type blocklistState struct {
+	mu          sync.RWMutex
+	list        *iplist.IPList
+	ranges      int
+	lastRefresh time.Time
+}
+
+func (b *blocklistState) set(list *iplist.IPList) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.list = list
+
+	b.ranges = 0
+	if list != nil {
+		b.ranges = list.NumRanges()
+	}
+
+	b.lastRefresh = time.Now()
+}
+
+func (b *blocklistState) Lookup(ip net.IP) (iplist.Range, bool) {
+	b.mu.RLock()
+	list := b.list
+	b.mu.RUnlock()
+
+	if list == nil {
+		return iplist.Range{}, false
+	}
+
+	return list.Lookup(ip)
+}
+
+func (b *blocklistState) NumRanges() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.ranges
+}
+
+func (b *blocklistState) get() (int, time.Time) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.ranges, b.lastRefresh
+}
+
 func NewGateway(
 	laddr string,
 	storage string,
@@ -56,6 +204,17 @@ func NewGateway(
 	upnp bool,
 	protocols []string,
 	downloadDir string,
+	webSeeds []string,
+	maxUploadBytesPerSec int64,
+	maxDownloadBytesPerSec int64,
+	blocklist string,
+	blocklistURL string,
+	blocklistRefresh time.Duration,
+	storageBackend string,
+	seed bool,
+	seedRatio float64,
+	watchDir string,
+	readaheadBytes int64,
 	onDownloadProgress func(torrentMetrics v1.TorrentMetrics, fileMetrics v1.FileMetrics),
 		ctx context.Context,
 ) *Gateway {
@@ -70,6 +229,22 @@ func NewGateway(
 		upnp:        upnp,
 		protocols:   protocols,
 		downloadDir: downloadDir,
+		webSeeds:    webSeeds,
+		maxUploadBytesPerSec:   maxUploadBytesPerSec,
+		maxDownloadBytesPerSec: maxDownloadBytesPerSec,
+		blocklist:        blocklist,
+		blocklistURL:     blocklistURL,
+		blocklistRefresh: blocklistRefresh,
+		storageBackend:   storageBackend,
+		seed:             seed,
+		seedRatio:        seedRatio,
+		watchDir:         watchDir,
+		readaheadBytes:   readaheadBytes,
+
+		seeding: seedingSet{
+			path: filepath.Join(storage, "seeding.json"),
+			set:  make(map[string]struct{}),
+		},
 
 		onDownloadProgress: onDownloadProgress,
 
@@ -79,6 +254,464 @@ func NewGateway(
 	}
 }
 
+// addWebSeeds attaches BEP 19 webseeds to t, combining the gateway's
+// configured webseeds, the comma-separated `webseeds` query parameter and
+// any `url-list` entries already present in the torrent's metainfo.
+func (g *Gateway) addWebSeeds(t *torrent.Torrent, requested string) {
+	webSeeds := append([]string{}, g.webSeeds...)
+
+	if requested != "" {
+		for _, u := range strings.Split(requested, ",") {
+			webSeeds = append(webSeeds, strings.TrimSpace(u))
+		}
+	}
+
+	webSeeds = append(webSeeds, t.Metainfo().UrlList...)
+
+	if len(webSeeds) > 0 {
+		log.Debug().
+		Strs("webSeeds", webSeeds).
+		Str("infoHash", t.InfoHash().HexString()).
+		Msg("Adding web seeds")
+
+		t.AddWebSeeds(webSeeds)
+	}
+}
+
+// newRateLimiter returns a rate.Limiter enforcing bytesPerSec, or an
+// unlimited one if bytesPerSec is 0.
+func newRateLimiter(bytesPerSec int64) *rate.Limiter {
+	if bytesPerSec <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+
+	return rate.NewLimiter(rate.Limit(bytesPerSec), defaultRateLimiterBurst)
+}
+
+// rateLimitedReadSeeker throttles reads from an io.ReadSeeker to a configured
+// rate, used to honor the per-request `maxDownloadRate` override on /stream.
+type rateLimitedReadSeeker struct {
+	io.ReadSeeker
+
+	ctx     context.Context
+	limiter *rate.Limiter
+}
+
+func (r *rateLimitedReadSeeker) Read(p []byte) (int, error) {
+	n, err := r.ReadSeeker.Read(p)
+	if n > 0 {
+		if werr := r.limiter.WaitN(r.ctx, n); werr != nil {
+			return n, werr
+		}
+	}
+
+	return n, err
+}
+
+// loadBlocklist fetches and parses the configured IP blocklist, preferring
+// blocklistURL over blocklist if both are set. It returns (nil, nil) if
+// neither is configured.
+func (g *Gateway) loadBlocklist() (*iplist.IPList, error) {
+	switch {
+	case g.blocklistURL != "":
+		res, err := http.Get(g.blocklistURL)
+		if err != nil {
+			return nil, err
+		}
+		defer res.Body.Close()
+
+		return iplist.NewFromReader(res.Body)
+	case g.blocklist != "":
+		f, err := os.Open(g.blocklist)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		return iplist.NewFromReader(f)
+	default:
+		return nil, nil
+	}
+}
+
+// refreshBlocklist periodically reloads the IP blocklist and swaps it into
+// g.blocklistState until g.ctx is canceled. g.blocklistState is installed as
+// cfg.IPBlocklist at client construction time, so the running client picks
+// up the new list on its very next lookup without needing to be rebuilt.
+func (g *Gateway) refreshBlocklist() {
+	ticker := time.NewTicker(g.blocklistRefresh)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-g.ctx.Done():
+			return
+		case <-ticker.C:
+			list, err := g.loadBlocklist()
+			if err != nil {
+				log.Error().Err(err).Msg("Could not refresh IP blocklist")
+
+				continue
+			}
+
+			g.blocklistState.set(list)
+
+			log.Info().Int("ranges", list.NumRanges()).Msg("IP blocklist refreshed")
+		}
+	}
+}
+
+// newStorageImpl constructs the storage.ClientImpl for the given backend
+// name, rooted at baseDir. An empty backend name falls back to "file".
+// Unknown backends return ErrUnknownStorageBackend rather than silently
+// falling back, since picking the wrong one can silently change durability
+// and performance characteristics.
+func newStorageImpl(backend string, baseDir string) (storage.ClientImpl, error) {
+	switch backend {
+	case "", "file":
+		// Plain per-file on-disk storage; portable, but every read/write goes
+		// through the regular file I/O path.
+		return storage.NewFile(baseDir), nil
+	case "mmap":
+		// Memory-maps each file, letting the OS page cache serve repeated reads
+		// (e.g. seeking around in /stream) without extra copies.
+		return storage.NewMMap(baseDir), nil
+	case "piece-file":
+		// Deprecated upstream, but kept as a distinct choice: plain per-file
+		// storage like "file", except files are laid out under a path keyed
+		// by infohash instead of by torrent name. Same pre-allocation and I/O
+		// behavior as "file" otherwise.
+		return storage.NewFileByInfoHash(baseDir), nil
+	case "boltdb":
+		// Keeps all piece data in a single boltdb file, trading some per-read
+		// overhead for fewer open file descriptors.
+		return storage.NewBoltDB(filepath.Join(baseDir, "bolt.db")), nil
+	default:
+		return nil, ErrUnknownStorageBackend
+	}
+}
+
+// enforceSeedRatio periodically drops torrents that have been seeded past
+// g.seedRatio (BytesWrittenData / BytesReadUsefulData) until g.ctx is
+// canceled.
+func (g *Gateway) enforceSeedRatio() {
+	ticker := time.NewTicker(seedRatioCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-g.ctx.Done():
+			return
+		case <-ticker.C:
+			for _, t := range g.torrentClient.Torrents() {
+				stats := t.Stats()
+
+				read := stats.BytesReadUsefulData.Int64()
+				if read == 0 {
+					continue
+				}
+
+				ratio := float64(stats.BytesWrittenData.Int64()) / float64(read)
+				if ratio > g.seedRatio {
+					log.Info().
+					Str("infoHash", t.InfoHash().HexString()).
+					Float64("ratio", ratio).
+					Msg("Seed ratio reached, dropping torrent")
+
+					mi := t.Metainfo()
+					if info, err := mi.UnmarshalInfo(); err == nil {
+						if err := g.seeding.remove(mi.Magnet(nil, &info).String()); err != nil {
+							log.Error().Err(err).Msg("Could not persist seeding set")
+						}
+					}
+
+					t.Drop()
+				}
+			}
+		}
+	}
+}
+
+// watchTorrentDir watches g.watchDir for .torrent files being added or
+// removed, adding or dropping the corresponding torrent and persisting
+// additions to the seeding set so they resume on restart.
+func (g *Gateway) watchTorrentDir() {
+	dw, err := dirwatch.New(g.watchDir)
+	if err != nil {
+		log.Error().Err(err).Str("watchDir", g.watchDir).Msg("Could not watch directory for torrents")
+
+		return
+	}
+	defer dw.Close()
+
+	for {
+		select {
+		case <-g.ctx.Done():
+			return
+		case change, ok := <-dw.Events:
+			if !ok {
+				return
+			}
+
+			switch change.Change {
+			case dirwatch.Added:
+				t, err := g.torrentClient.AddTorrentFromFile(change.TorrentFilePath)
+				if err != nil {
+					log.Error().Err(err).Str("path", change.TorrentFilePath).Msg("Could not add watched torrent")
+
+					continue
+				}
+
+				<-t.GotInfo()
+
+				mi := t.Metainfo()
+				if info, err := mi.UnmarshalInfo(); err == nil {
+					if err := g.seeding.add(mi.Magnet(nil, &info).String()); err != nil {
+						log.Error().Err(err).Msg("Could not persist seeding set")
+					}
+				}
+
+				log.Info().Str("path", change.TorrentFilePath).Msg("Added watched torrent")
+			case dirwatch.Removed:
+				if t, ok := g.torrentClient.Torrent(change.InfoHash); ok {
+					mi := t.Metainfo()
+					if info, err := mi.UnmarshalInfo(); err == nil {
+						if err := g.seeding.remove(mi.Magnet(nil, &info).String()); err != nil {
+							log.Error().Err(err).Msg("Could not persist seeding set")
+						}
+					}
+
+					t.Drop()
+
+					log.Info().Str("infoHash", change.InfoHash.HexString()).Msg("Dropped watched torrent")
+				}
+			}
+		}
+	}
+}
+
+// readaheadState reports the current /stream readahead window so the
+// progress ticker can include it in onDownloadProgress callbacks.
+type readaheadState struct {
+	mu              sync.Mutex
+	headPiece       int
+	readaheadPieces int
+}
+
+func (s *readaheadState) get() (int, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.headPiece, s.readaheadPieces
+}
+
+func (s *readaheadState) set(headPiece, readaheadPieces int) {
+	s.mu.Lock()
+	s.headPiece = headPiece
+	s.readaheadPieces = readaheadPieces
+	s.mu.Unlock()
+}
+
+// readaheadReadSeeker wraps a torrent.Reader so that, on every Seek, it
+// recomputes a piece-priority window around the new offset: the piece at the
+// offset is fetched immediately, the next readaheadPieces pieces are
+// prioritized for readahead, and everything else reverts to normal priority.
+type readaheadReadSeeker struct {
+	torrent.Reader
+
+	f         *torrent.File
+	readahead int64
+	state     *readaheadState
+}
+
+func newReadaheadReadSeeker(f *torrent.File, readahead int64, state *readaheadState) *readaheadReadSeeker {
+	reader := f.NewReader()
+	reader.SetReadahead(readahead)
+	reader.SetResponsive()
+
+	r := &readaheadReadSeeker{Reader: reader, f: f, readahead: readahead, state: state}
+	r.updatePriorities(0)
+
+	return r
+}
+
+func (r *readaheadReadSeeker) Seek(offset int64, whence int) (int64, error) {
+	pos, err := r.Reader.Seek(offset, whence)
+	if err != nil {
+		return pos, err
+	}
+
+	r.updatePriorities(pos)
+
+	return pos, nil
+}
+
+func (r *readaheadReadSeeker) updatePriorities(fileOffset int64) {
+	t := r.f.Torrent()
+
+	pieceLength := t.Info().PieceLength
+	if pieceLength == 0 {
+		return
+	}
+
+	headPiece := int((r.f.Offset() + fileOffset) / pieceLength)
+	readaheadPieces := int(r.readahead/pieceLength) + 1
+
+	// Scope the window to r.f's own pieces. In a multi-file torrent, pieces
+	// outside that range may belong to another file with its own in-flight
+	// /stream request, and resetting their priority here would stomp on it.
+	for i := r.f.BeginPieceIndex(); i < r.f.EndPieceIndex(); i++ {
+		switch {
+		case i == headPiece:
+			t.Piece(i).SetPriority(torrent.PiecePriorityNow)
+		case i > headPiece && i <= headPiece+readaheadPieces:
+			t.Piece(i).SetPriority(torrent.PiecePriorityReadahead)
+		default:
+			t.Piece(i).SetPriority(torrent.PiecePriorityNormal)
+		}
+	}
+
+	r.state.set(headPiece, readaheadPieces)
+}
+
+// collectMetrics builds a v1.TorrentMetrics snapshot for every active
+// torrent, sampling byte counters over sampleInterval to derive instantaneous
+// download/upload rates.
+func (g *Gateway) collectMetrics(sampleInterval time.Duration) []v1.TorrentMetrics {
+	torrents := g.torrentClient.Torrents()
+
+	// Sample BytesReadUsefulData/BytesWrittenData over sampleInterval to
+	// derive an instantaneous rate instead of just reporting BytesCompleted.
+	statsBefore := make([]torrent.TorrentStats, len(torrents))
+	for i, t := range torrents {
+		statsBefore[i] = t.Stats()
+	}
+
+	time.Sleep(sampleInterval)
+
+	metrics := []v1.TorrentMetrics{}
+	for i, t := range torrents {
+		mi := t.Metainfo()
+
+		info, err := mi.UnmarshalInfo()
+		if err != nil {
+			log.Error().
+			Err(err).
+			Msg("Could not unmarshal metainfo")
+
+			continue
+		}
+
+		fileMetrics := []v1.FileMetrics{}
+		for _, f := range t.Files() {
+			fileMetrics = append(fileMetrics, v1.FileMetrics{
+				Path:      f.Path(),
+					     Length:    f.Length(),
+					     Completed: f.BytesCompleted(),
+			})
+		}
+
+		statsAfter := t.Stats()
+		downloadRate := int64(float64(statsAfter.BytesReadUsefulData.Int64()-statsBefore[i].BytesReadUsefulData.Int64()) / sampleInterval.Seconds())
+		uploadRate := int64(float64(statsAfter.BytesWrittenData.Int64()-statsBefore[i].BytesWrittenData.Int64()) / sampleInterval.Seconds())
+
+		bytesWritten := statsAfter.BytesWrittenData.Int64()
+		var seedRatio float64
+		if read := statsAfter.BytesReadUsefulData.Int64(); read > 0 {
+			seedRatio = float64(bytesWritten) / float64(read)
+		}
+
+		torrentMetrics := v1.TorrentMetrics{
+			Magnet:   mi.Magnet(nil, &info).String(),
+	       InfoHash: mi.HashInfoBytes().HexString(),
+	       Peers:    len(t.PeerConns()),
+	       Files:    fileMetrics,
+	       BytesCompleted: t.BytesCompleted(),
+	       Length:         t.Length(),
+	       PieceStateRuns: pieceStateRuns(t),
+	       DownloadRate:    downloadRate,
+	       UploadRate:      uploadRate,
+	       MaxDownloadRate: g.maxDownloadBytesPerSec,
+	       MaxUploadRate:   g.maxUploadBytesPerSec,
+	       BytesWritten: bytesWritten,
+	       SeedRatio:    seedRatio,
+	       IsSeeding:    g.seed,
+	       HalfOpenPeers: statsAfter.HalfOpenPeers,
+	       ActivePeers:   statsAfter.ActivePeers,
+	       PeerDetails:   peerDetails(t, downloadRate),
+		}
+
+		metrics = append(metrics, torrentMetrics)
+	}
+
+	return metrics
+}
+
+// pieceStateRuns converts Torrent.PieceStateRuns() into the run-length-encoded
+// wire format used by /metrics, translating piece counts into byte offsets.
+func pieceStateRuns(t *torrent.Torrent) []v1.PieceStateRun {
+	pieceLength := t.Info().PieceLength
+	torrentLength := t.Length()
+
+	runs := []v1.PieceStateRun{}
+	var offset int64
+	for _, run := range t.PieceStateRuns() {
+		length := int64(run.Length) * pieceLength
+		if offset+length > torrentLength {
+			// the torrent's final piece is usually shorter than pieceLength
+			length = torrentLength - offset
+		}
+
+		runs = append(runs, v1.PieceStateRun{
+			Offset:   offset,
+			Length:   length,
+			Complete: run.Complete,
+			Partial:  run.Partial,
+			Checking: run.Checking,
+		})
+
+		offset += length
+	}
+
+	return runs
+}
+
+// peerDetails reports each connection to t's swarm, including webseeds,
+// using downloadRate as a shared estimate since anacrolix/torrent does not
+// expose a per-peer byte rate. anacrolix/torrent has no dedicated accessor
+// for a webseed's URL either; Peer.RemoteAddr is set to it when the webseed
+// is added, so that's what's reported here instead of a URL field.
+func peerDetails(t *torrent.Torrent, downloadRate int64) []v1.Peer {
+	conns := t.PeerConns()
+
+	// anacrolix/torrent does not expose a per-peer byte rate, so split the
+	// torrent's instantaneous rate evenly across its active connections.
+	perPeerRate := float64(downloadRate)
+	if n := len(conns); n > 0 {
+		perPeerRate /= float64(n)
+	}
+
+	peers := []v1.Peer{}
+
+	for _, pc := range conns {
+		peers = append(peers, v1.Peer{
+			Addr:         pc.RemoteAddr.String(),
+			ClientID:     fmt.Sprintf("%x", pc.PeerID),
+			DownloadRate: perPeerRate,
+			IsWebSeed:    false,
+		})
+	}
+
+	for _, wc := range t.WebseedPeerConns() {
+		peers = append(peers, v1.Peer{
+			Addr:      wc.RemoteAddr.String(),
+			IsWebSeed: true,
+		})
+	}
+
+	return peers
+}
+
 func (g *Gateway) Open() error {
 	log.Trace().Msg("Opening gateway")
 
@@ -101,7 +734,11 @@ func (g *Gateway) Open() error {
 	}
 
 	// Configure storage to use the download directory
-	cfg.DefaultStorage = storage.NewFile(downloadBaseDir)
+	storageImpl, err := newStorageImpl(g.storageBackend, downloadBaseDir)
+	if err != nil {
+		return err
+	}
+	cfg.DefaultStorage = storageImpl
 
 	torrentPort, err := freeport.GetFreePort()
 	if err != nil {
@@ -131,6 +768,12 @@ func (g *Gateway) Open() error {
 		log.Info().Msg("UPnP port forwarding disabled")
 	}
 
+	// Configure seeding
+	cfg.Seed = g.seed
+	if g.seed {
+		log.Info().Msg("Seeding enabled")
+	}
+
 	// Configure protocols
 	if len(g.protocols) > 0 {
 		// Start with all protocols disabled
@@ -158,6 +801,31 @@ func (g *Gateway) Open() error {
 		}
 	}
 
+	// Configure upload/download rate limits
+	cfg.UploadRateLimiter = newRateLimiter(g.maxUploadBytesPerSec)
+	cfg.DownloadRateLimiter = newRateLimiter(g.maxDownloadBytesPerSec)
+	if g.maxUploadBytesPerSec > 0 {
+		log.Info().Int64("maxUploadBytesPerSec", g.maxUploadBytesPerSec).Msg("Upload rate limit configured")
+	}
+	if g.maxDownloadBytesPerSec > 0 {
+		log.Info().Int64("maxDownloadBytesPerSec", g.maxDownloadBytesPerSec).Msg("Download rate limit configured")
+	}
+
+	// Configure IP blocklist. g.blocklistState is installed as cfg.IPBlocklist
+	// rather than the *iplist.IPList itself, so refreshBlocklist can swap the
+	// list it holds later without rebuilding the client.
+	if g.blocklist != "" || g.blocklistURL != "" {
+		list, err := g.loadBlocklist()
+		if err != nil {
+			return err
+		}
+
+		g.blocklistState.set(list)
+		cfg.IPBlocklist = &g.blocklistState
+
+		log.Info().Int("ranges", list.NumRanges()).Msg("IP blocklist loaded")
+	}
+
 	// Set peer connection parameters
 	cfg.MinPeerExtensions.SetBit(0, true)
 
@@ -167,6 +835,31 @@ func (g *Gateway) Open() error {
 	}
 	g.torrentClient = c
 
+	if g.blocklistRefresh > 0 && (g.blocklist != "" || g.blocklistURL != "") {
+		go g.refreshBlocklist()
+	}
+
+	if g.seed {
+		magnets, err := g.seeding.load()
+		if err != nil {
+			return err
+		}
+
+		for _, magnet := range magnets {
+			if _, err := c.AddMagnet(magnet); err != nil {
+				log.Error().Err(err).Str("magnet", magnet).Msg("Could not restore persisted seeding torrent")
+			}
+		}
+
+		if g.seedRatio > 0 {
+			go g.enforceSeedRatio()
+		}
+	}
+
+	if g.watchDir != "" {
+		go g.watchTorrentDir()
+	}
+
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("/info", func(w http.ResponseWriter, r *http.Request) {
@@ -187,6 +880,14 @@ func (g *Gateway) Open() error {
 		}
 		<-t.GotInfo()
 
+		g.addWebSeeds(t, r.URL.Query().Get("webseeds"))
+
+		if g.seed {
+			if err := g.seeding.add(magnetLink); err != nil {
+				log.Error().Err(err).Msg("Could not persist seeding set")
+			}
+		}
+
 		info := v1.Info{
 			Files: []v1.File{},
 		}
@@ -235,40 +936,74 @@ func (g *Gateway) Open() error {
 		log.Debug().
 		Msg("Getting metrics")
 
-		metrics := []v1.TorrentMetrics{}
-		for _, t := range g.torrentClient.Torrents() {
-			mi := t.Metainfo()
+		enc := json.NewEncoder(w)
+		if err := enc.Encode(g.collectMetrics(metricsSampleInterval)); err != nil {
+			panic(err)
+		}
+	})
 
-			info, err := mi.UnmarshalInfo()
-			if err != nil {
-				log.Error().
-				Err(err).
-				Msg("Could not unmarshal metainfo")
+	mux.HandleFunc("/metrics/stream", func(w http.ResponseWriter, r *http.Request) {
+		log.Debug().
+		Msg("Streaming metrics")
 
-				continue
-			}
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
 
-			fileMetrics := []v1.FileMetrics{}
-			for _, f := range t.Files() {
-				fileMetrics = append(fileMetrics, v1.FileMetrics{
-					Path:      f.Path(),
-						     Length:    f.Length(),
-						     Completed: f.BytesCompleted(),
-				})
-			}
+			return
+		}
 
-			torrentMetrics := v1.TorrentMetrics{
-				Magnet:   mi.Magnet(nil, &info).String(),
-		       InfoHash: mi.HashInfoBytes().HexString(),
-		       Peers:    len(t.PeerConns()),
-		       Files:    fileMetrics,
+		interval := metricsSampleInterval
+		if v := r.URL.Query().Get("interval"); v != "" {
+			parsed, err := strconv.Atoi(v)
+			if err != nil || parsed <= 0 {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+
+				panic(fmt.Errorf("invalid interval %q: must be a positive number of seconds", v))
 			}
 
-			metrics = append(metrics, torrentMetrics)
+			interval = time.Duration(parsed) * time.Second
 		}
 
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-ticker.C:
+				data, err := json.Marshal(g.collectMetrics(metricsSampleInterval))
+				if err != nil {
+					log.Error().Err(err).Msg("Could not marshal metrics snapshot")
+
+					continue
+				}
+
+				if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+					return
+				}
+
+				flusher.Flush()
+			}
+		}
+	})
+
+	mux.HandleFunc("/blocklist", func(w http.ResponseWriter, r *http.Request) {
+		log.Debug().
+		Msg("Getting blocklist status")
+
+		ranges, lastRefresh := g.blocklistState.get()
+
 		enc := json.NewEncoder(w)
-		if err := enc.Encode(metrics); err != nil {
+		if err := enc.Encode(v1.BlocklistStatus{
+			Ranges:      ranges,
+			LastRefresh: lastRefresh,
+		}); err != nil {
 			panic(err)
 		}
 	})
@@ -317,6 +1052,38 @@ func (g *Gateway) Open() error {
 		}
 		<-t.GotInfo()
 
+		g.addWebSeeds(t, r.URL.Query().Get("webseeds"))
+
+		if g.seed {
+			if err := g.seeding.add(magnetLink); err != nil {
+				log.Error().Err(err).Msg("Could not persist seeding set")
+			}
+		}
+
+		// maxUploadRate has no per-request override here: /stream only ever
+		// reads torrent data to serve it to this HTTP client, so there's no
+		// distinct "upload" direction on this path to throttle. Swarm upload
+		// (seeding) is rate-limited globally by --max-upload-rate instead.
+		var maxDownloadRate int64
+		if v := r.URL.Query().Get("maxDownloadRate"); v != "" {
+			parsed, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				panic(err)
+			}
+
+			maxDownloadRate = parsed
+		}
+
+		readahead := g.readaheadBytes
+		if v := r.URL.Query().Get("readahead"); v != "" {
+			parsed, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				panic(err)
+			}
+
+			readahead = parsed
+		}
+
 		found := false
 		for _, l := range t.Files() {
 			f := l
@@ -327,6 +1094,8 @@ func (g *Gateway) Open() error {
 
 			found = true
 
+			streamReadahead := &readaheadState{}
+
 			go func() {
 				tick := time.NewTicker(time.Millisecond * 100)
 				defer tick.Stop()
@@ -335,6 +1104,8 @@ func (g *Gateway) Open() error {
 				for range tick.C {
 					if completed, length := f.BytesCompleted(), f.Length(); completed < length {
 						if completed != lastCompleted {
+							headPiece, readaheadPieces := streamReadahead.get()
+
 							g.onDownloadProgress(
 								v1.TorrentMetrics{
 									Magnet: magnetLink,
@@ -345,6 +1116,8 @@ func (g *Gateway) Open() error {
 				    Path:      f.Path(),
 									     Length:    length,
 									     Completed: completed,
+									     HeadPiece:       headPiece,
+									     ReadaheadPieces: readaheadPieces,
 			    },
 							)
 						}
@@ -361,7 +1134,19 @@ func (g *Gateway) Open() error {
 			Str("path", requestedPath).
 			Msg("Got stream")
 
-			http.ServeContent(w, r, f.DisplayPath(), time.Unix(f.Torrent().Metainfo().CreationDate, 0), f.NewReader())
+			var content io.ReadSeeker = newReadaheadReadSeeker(f, readahead, streamReadahead)
+			if maxDownloadRate > 0 {
+				// Pacing reads off of f throttles how fast the reader consumes
+				// completed pieces, which in turn throttles how fast the torrent
+				// fetches new ones for this stream.
+				content = &rateLimitedReadSeeker{
+					ReadSeeker: content,
+					ctx:        r.Context(),
+					limiter:    newRateLimiter(maxDownloadRate),
+				}
+			}
+
+			http.ServeContent(w, r, f.DisplayPath(), time.Unix(f.Torrent().Metainfo().CreationDate, 0), content)
 		}
 
 		if !found {